@@ -0,0 +1,8 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package arbutil
+
+// EigenDABlob keys preimages recovered from an EigenDA blob by the Keccak256
+// hash of its dispersal commitment.
+const EigenDABlob PreimageType = EthVersionedHashPreimageType + 1
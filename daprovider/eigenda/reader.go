@@ -0,0 +1,89 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// Package eigenda implements a daprovider.Reader (and daprovider.Writer) pair
+// for batches posted to EigenDA. Blobs are addressed by their dispersal
+// commitment, which is carried in the sequencer message after the header
+// byte; RecoverPayloadFromBatch fetches the blob, verifies its KZG
+// commitment against the posted blob header when validateSeqMsg is set, and
+// records the payload under arbutil.EigenDABlob, keyed by the Keccak256 hash
+// of the commitment, so it can be replayed without a live EigenDA client.
+package eigenda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/daprovider"
+)
+
+// HeaderByte identifies a sequencer message whose payload is an EigenDA
+// dispersal commitment rather than raw calldata or a DAS certificate.
+const HeaderByte byte = 0x0a
+
+// BlobFetcher retrieves a previously dispersed blob and its header from an
+// EigenDA disperser or retriever endpoint.
+type BlobFetcher interface {
+	FetchBlob(ctx context.Context, commitment []byte) (blob []byte, blobHeader []byte, err error)
+}
+
+// KZGVerifier checks that blob commits to blobHeader under EigenDA's KZG
+// commitment scheme.
+type KZGVerifier interface {
+	VerifyCommitment(blobHeader []byte, blob []byte) error
+}
+
+type Reader struct {
+	fetcher  BlobFetcher
+	verifier KZGVerifier
+}
+
+func NewReader(fetcher BlobFetcher, verifier KZGVerifier) *Reader {
+	return &Reader{
+		fetcher:  fetcher,
+		verifier: verifier,
+	}
+}
+
+func (r *Reader) IsValidHeaderByte(ctx context.Context, headerByte byte) bool {
+	return headerByte == HeaderByte
+}
+
+func (r *Reader) RecoverPayloadFromBatch(
+	ctx context.Context,
+	batchNum uint64,
+	batchBlockHash common.Hash,
+	sequencerMsg []byte,
+	preimages daprovider.PreimagesMap,
+	validateSeqMsg bool,
+) ([]byte, daprovider.PreimagesMap, error) {
+	if len(sequencerMsg) <= 41 {
+		return nil, nil, fmt.Errorf("eigenda: sequencer message too short to carry a commitment")
+	}
+	commitment := sequencerMsg[41:]
+
+	blob, blobHeader, err := r.fetcher.FetchBlob(ctx, commitment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("eigenda: fetching blob for batch %d: %w", batchNum, err)
+	}
+	if validateSeqMsg {
+		if err := r.verifier.VerifyCommitment(blobHeader, blob); err != nil {
+			return nil, nil, fmt.Errorf("eigenda: commitment verification failed for batch %d: %w", batchNum, err)
+		}
+	}
+
+	if preimages == nil {
+		preimages = make(daprovider.PreimagesMap)
+	}
+	hash := crypto.Keccak256Hash(commitment)
+	if preimages[arbutil.EigenDABlob] == nil {
+		preimages[arbutil.EigenDABlob] = make(map[common.Hash][]byte)
+	}
+	preimages[arbutil.EigenDABlob][hash] = blob
+
+	return blob, preimages, nil
+}
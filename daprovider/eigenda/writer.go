@@ -0,0 +1,41 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package eigenda
+
+import (
+	"context"
+	"fmt"
+)
+
+// Disperser submits blobs to EigenDA and returns the dispersal commitment
+// that uniquely identifies them for later retrieval.
+type Disperser interface {
+	DisperseBlob(ctx context.Context, data []byte, timeout uint64) (commitment []byte, err error)
+}
+
+type Writer struct {
+	disperser Disperser
+}
+
+func NewWriter(disperser Disperser) *Writer {
+	return &Writer{disperser: disperser}
+}
+
+func (w *Writer) Store(ctx context.Context, message []byte, timeout uint64) ([]byte, error) {
+	commitment, err := w.disperser.DisperseBlob(ctx, message, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("eigenda: dispersing blob: %w", err)
+	}
+	cert := make([]byte, 0, 1+len(commitment))
+	cert = append(cert, HeaderByte)
+	cert = append(cert, commitment...)
+	return cert, nil
+}
+
+func (w *Writer) CommitBatch(ctx context.Context, cert []byte) error {
+	// EigenDA confirms availability asynchronously on-chain; dispersal in
+	// Store already returns the commitment needed to post to the inbox, so
+	// there is nothing further to commit here.
+	return nil
+}
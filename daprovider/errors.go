@@ -0,0 +1,10 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package daprovider
+
+import "errors"
+
+// ErrNoReaderForHeaderByte is returned when a sequencer message carries a
+// non-DAS header byte that does not match any registered Reader/Writer pair.
+var ErrNoReaderForHeaderByte = errors.New("no daprovider registered for header byte")
@@ -0,0 +1,25 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package daprovider
+
+import (
+	"context"
+)
+
+// Writer mirrors Reader, giving a DA backend (DAS, EigenDA, Celestia, ...) a
+// symmetric way to post batch data and be queried back by header byte. A
+// Writer is associated with exactly one Reader via the same header byte, so
+// the pair can be registered and looked up together.
+type Writer interface {
+	// Store posts message to the DA backend and returns the serialized
+	// certificate (including the backend's header byte) that should be
+	// posted to the sequencer inbox in place of the raw message.
+	Store(ctx context.Context, message []byte, timeout uint64) ([]byte, error)
+
+	// CommitBatch finalizes a batch of already-Stored messages, if the
+	// backend distinguishes dispersal from on-chain commitment. Backends
+	// whose Store already returns everything needed to post to the inbox
+	// (such as EigenDA) may implement this as a no-op.
+	CommitBatch(ctx context.Context, cert []byte) error
+}
@@ -0,0 +1,187 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/util/containers"
+)
+
+var (
+	batchCacheHitCounter  = metrics.NewRegisteredCounter("arb/blockvalidator/batchcache/hit", nil)
+	batchCacheMissCounter = metrics.NewRegisteredCounter("arb/blockvalidator/batchcache/miss", nil)
+)
+
+// batchCacheDBPrefix namespaces on-disk batch cache entries within the
+// shared arbitrum database handle.
+var batchCacheDBPrefix = []byte("bv-batchcache-")
+
+func batchCacheDBKey(batchNum uint64) []byte {
+	key := make([]byte, len(batchCacheDBPrefix)+8)
+	copy(key, batchCacheDBPrefix)
+	binary.BigEndian.PutUint64(key[len(batchCacheDBPrefix):], batchNum)
+	return key
+}
+
+type cachedBatch struct {
+	Info      *FullBatchInfo
+	expiresAt time.Time
+}
+
+// batchCache is a bounded in-memory LRU of FullBatchInfo backed by an
+// optional on-disk layer in the node's ethdb, so a validator processing many
+// contiguous messages that share a DA batch doesn't re-run
+// RecoverPayloadFromBatch (or re-fetch previous batch bodies) once per
+// message. Entries are invalidated wholesale from a given batch number
+// onward when the chain reorgs underneath the validator.
+type batchCache struct {
+	mu  sync.Mutex
+	lru *containers.LruCache[uint64, *cachedBatch]
+	db  ethdb.Database // nil disables the on-disk layer
+	ttl time.Duration  // zero disables expiry
+
+	// lastBatchCount is the highest GetBatchCount() observed so far. A
+	// later observation lower than this means the batches at and after
+	// the new count were reorged away, so any FullBatchInfo cached for
+	// them is stale.
+	lastBatchCount uint64
+}
+
+// newBatchCache builds an enabled cache of the given size. Callers that want
+// the cache disabled entirely (BatchCacheSize == 0) should skip calling this
+// and leave batchCache nil rather than constructing one here, since an LRU
+// can't itself hold zero entries.
+func newBatchCache(size int, ttl time.Duration, db ethdb.Database) *batchCache {
+	if size <= 0 {
+		size = 1
+	}
+	return &batchCache{
+		lru: containers.NewLruCache[uint64, *cachedBatch](size),
+		db:  db,
+		ttl: ttl,
+	}
+}
+
+func (c *batchCache) get(batchNum uint64) (*FullBatchInfo, bool) {
+	c.mu.Lock()
+	entry, ok := c.lru.Get(batchNum)
+	c.mu.Unlock()
+	if ok {
+		if c.ttl == 0 || time.Now().Before(entry.expiresAt) {
+			batchCacheHitCounter.Inc(1)
+			return entry.Info, true
+		}
+		c.mu.Lock()
+		c.lru.Remove(batchNum)
+		c.mu.Unlock()
+	}
+
+	if c.db != nil {
+		data, err := c.db.Get(batchCacheDBKey(batchNum))
+		if err == nil {
+			var info FullBatchInfo
+			if err := json.Unmarshal(data, &info); err == nil {
+				c.put(batchNum, &info)
+				batchCacheHitCounter.Inc(1)
+				return &info, true
+			}
+			log.Warn("batch cache: failed decoding on-disk entry, ignoring", "batch", batchNum, "err", err)
+		}
+	}
+	batchCacheMissCounter.Inc(1)
+	return nil, false
+}
+
+func (c *batchCache) put(batchNum uint64, info *FullBatchInfo) {
+	c.mu.Lock()
+	c.lru.Add(batchNum, &cachedBatch{Info: info, expiresAt: time.Now().Add(c.ttl)})
+	c.mu.Unlock()
+
+	if c.db == nil {
+		return
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		log.Warn("batch cache: failed encoding entry for disk", "batch", batchNum, "err", err)
+		return
+	}
+	if err := c.db.Put(batchCacheDBKey(batchNum), data); err != nil {
+		log.Warn("batch cache: failed persisting entry to disk", "batch", batchNum, "err", err)
+	}
+}
+
+// invalidateFrom drops every cached entry for a batch number >= from, used
+// when a reorg means previously recovered batch data can no longer be
+// trusted.
+func (c *batchCache) invalidateFrom(from uint64) {
+	c.mu.Lock()
+	for _, batchNum := range c.lru.Keys() {
+		if batchNum >= from {
+			c.lru.Remove(batchNum)
+		}
+	}
+	c.mu.Unlock()
+
+	if c.db == nil {
+		return
+	}
+	iter := c.db.NewIterator(batchCacheDBPrefix, binary.BigEndian.AppendUint64(nil, from))
+	defer iter.Release()
+	for iter.Next() {
+		if err := c.db.Delete(iter.Key()); err != nil {
+			log.Warn("batch cache: failed deleting invalidated on-disk entry", "err", err)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		log.Warn("batch cache: error iterating on-disk entries for invalidation", "err", err)
+	}
+}
+
+// observeBatchCount hooks readFullBatch/readPostedBatch's existing
+// GetBatchCount() calls into reorg detection: if the inbox tracker's batch
+// count has dropped since it was last observed, the batches from the new
+// count onward were reorged away, so any cached FullBatchInfo for them
+// would be stale and must be invalidated before it can be served again.
+func (c *batchCache) observeBatchCount(v *StatelessBlockValidator, batchCount uint64) {
+	c.mu.Lock()
+	last := c.lastBatchCount
+	c.lastBatchCount = batchCount
+	c.mu.Unlock()
+
+	if batchCount < last {
+		v.InvalidateBatchCacheFrom(batchCount)
+	}
+}
+
+// InvalidateBatchCacheFrom drops any cached FullBatchInfo for batchNum or
+// later. It pauses streamer reorgs for the duration of the invalidation so
+// a concurrent readFullBatch can't repopulate the cache with data that's
+// about to be reorged out from under it.
+func (v *StatelessBlockValidator) InvalidateBatchCacheFrom(batchNum uint64) {
+	if v.batchCache == nil {
+		return
+	}
+	v.streamer.PauseReorgs()
+	defer v.streamer.ResumeReorgs()
+	v.batchCache.invalidateFrom(batchNum)
+}
+
+func validateBatchCacheConfig(size int, ttl time.Duration) error {
+	if size < 0 {
+		return fmt.Errorf("batch cache size must be >= 0, got %d", size)
+	}
+	if ttl < 0 {
+		return fmt.Errorf("batch cache ttl must be >= 0, got %s", ttl)
+	}
+	return nil
+}
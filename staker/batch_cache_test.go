@@ -0,0 +1,95 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestBatchCachePutGet(t *testing.T) {
+	c := newBatchCache(4, 0, nil)
+	info := &FullBatchInfo{Number: 1, PostedData: []byte("batch-1")}
+	c.put(1, info)
+
+	got, ok := c.get(1)
+	if !ok {
+		t.Fatal("expected cache hit for batch 1")
+	}
+	if string(got.PostedData) != "batch-1" {
+		t.Fatalf("got unexpected posted data: %q", got.PostedData)
+	}
+
+	if _, ok := c.get(2); ok {
+		t.Fatal("expected cache miss for unpopulated batch 2")
+	}
+}
+
+func TestBatchCacheTTLExpiry(t *testing.T) {
+	c := newBatchCache(4, time.Millisecond, nil)
+	c.put(1, &FullBatchInfo{Number: 1, PostedData: []byte("batch-1")})
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.get(1); ok {
+		t.Fatal("expected cache entry to have expired")
+	}
+}
+
+func TestBatchCacheInvalidateFrom(t *testing.T) {
+	c := newBatchCache(8, 0, nil)
+	for i := uint64(0); i < 5; i++ {
+		c.put(i, &FullBatchInfo{Number: i, PostedData: []byte{byte(i)}})
+	}
+
+	c.invalidateFrom(3)
+
+	for i := uint64(0); i < 3; i++ {
+		if _, ok := c.get(i); !ok {
+			t.Fatalf("batch %d should still be cached", i)
+		}
+	}
+	for i := uint64(3); i < 5; i++ {
+		if _, ok := c.get(i); ok {
+			t.Fatalf("batch %d should have been invalidated", i)
+		}
+	}
+}
+
+func TestBatchCachePersistentLayer(t *testing.T) {
+	db := memorydb.New()
+	c := newBatchCache(1, 0, db)
+
+	// A second entry should evict the first from the in-memory LRU, but
+	// the on-disk layer should still be able to serve it.
+	c.put(1, &FullBatchInfo{Number: 1, PostedData: []byte("batch-1")})
+	c.put(2, &FullBatchInfo{Number: 2, PostedData: []byte("batch-2")})
+
+	got, ok := c.get(1)
+	if !ok {
+		t.Fatal("expected batch 1 to be served from the on-disk layer")
+	}
+	if string(got.PostedData) != "batch-1" {
+		t.Fatalf("got unexpected posted data: %q", got.PostedData)
+	}
+
+	c.invalidateFrom(1)
+	if _, err := db.Get(batchCacheDBKey(1)); err == nil {
+		t.Fatal("expected on-disk entry for batch 1 to be deleted after invalidation")
+	}
+}
+
+func TestValidateBatchCacheConfig(t *testing.T) {
+	if err := validateBatchCacheConfig(256, time.Hour); err != nil {
+		t.Fatalf("unexpected error for valid config: %v", err)
+	}
+	if err := validateBatchCacheConfig(-1, 0); err == nil {
+		t.Fatal("expected error for negative cache size")
+	}
+	if err := validateBatchCacheConfig(0, -time.Second); err == nil {
+		t.Fatal("expected error for negative ttl")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"time"
+
+	"github.com/offchainlabs/nitro/util/rpcclient"
+	"github.com/offchainlabs/nitro/validator/client/redis"
+)
+
+// BlockValidatorConfig carries everything StatelessBlockValidator and
+// BlockValidator need to configure their execution backends: the local
+// execution server pool, the optional redis-backed validation client, and
+// (as of this series) the multi-producer ValidationDispatcher and the
+// recovered-batch cache.
+type BlockValidatorConfig struct {
+	ValidationServerConfigs     []rpcclient.ClientConfig     `koanf:"validation-server-configs"`
+	RedisValidationClientConfig redis.ValidationClientConfig `koanf:"redis-validation-client-config"`
+	ValidationDispatcherConfig  ValidationDispatcherConfig   `koanf:"validation-dispatcher-config"`
+
+	// BatchCacheSize bounds the number of FullBatchInfo entries kept in the
+	// in-memory batch cache. Zero disables the cache.
+	BatchCacheSize int `koanf:"batch-cache-size"`
+	// BatchCacheTTL bounds how long an entry is served from the in-memory
+	// cache before it's treated as a miss there. Zero disables expiry.
+	// Entries read back from the on-disk layer (BatchCachePersistent) are
+	// served regardless of age.
+	BatchCacheTTL time.Duration `koanf:"batch-cache-ttl"`
+	// BatchCachePersistent additionally persists cache entries to the
+	// node's database, so they survive a restart.
+	BatchCachePersistent bool `koanf:"batch-cache-persistent"`
+}
+
+var DefaultBlockValidatorConfig = BlockValidatorConfig{
+	RedisValidationClientConfig: redis.DefaultValidationClientConfig,
+	ValidationDispatcherConfig:  DefaultValidationDispatcherConfig,
+	BatchCacheSize:              256,
+	BatchCacheTTL:               time.Hour,
+	BatchCachePersistent:        false,
+}
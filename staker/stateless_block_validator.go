@@ -34,6 +34,7 @@ type StatelessBlockValidator struct {
 	execSpawners     []validator.ExecutionSpawner
 	boldExecSpawners []validator.BOLDExecutionSpawner
 	redisValidator   *redis.ValidationClient
+	dispatcher       *ValidationDispatcher
 
 	recorder execution.ExecutionRecorder
 
@@ -41,11 +42,22 @@ type StatelessBlockValidator struct {
 	inboxTracker         InboxTrackerInterface
 	streamer             TransactionStreamerInterface
 	db                   ethdb.Database
-	dapReaders           []daprovider.Reader
+	batchCache           *batchCache
+	daProviders          []DAProvider
 	stack                *node.Node
 	latestWasmModuleRoot common.Hash
 }
 
+// DAProvider pairs a DA backend's Reader and Writer under the header byte
+// that identifies it in a posted sequencer message. Writer is optional: a
+// validator only ever reads batches, so providers registered purely for
+// validation (e.g. an archival node with no Store access) may leave it nil.
+type DAProvider struct {
+	HeaderByte byte
+	Reader     daprovider.Reader
+	Writer     daprovider.Writer
+}
+
 type BlockValidatorRegistrer interface {
 	SetBlockValidator(*BlockValidator)
 }
@@ -124,6 +136,12 @@ type FullBatchInfo struct {
 	PostedData []byte
 	MsgCount   arbutil.MessageIndex
 	Preimages  map[arbutil.PreimageType]map[common.Hash][]byte
+	// Acc is the batch accumulator hash GetBatchAcc reported for Number when
+	// this entry was populated. It identifies this exact batch, independent
+	// of GetBatchCount, so a cache hit can be rejected if batchNum was
+	// reorged and replaced by a different batch without the batch count
+	// ever dipping below batchNum.
+	Acc common.Hash
 }
 
 type validationEntry struct {
@@ -234,7 +252,7 @@ func NewStatelessBlockValidator(
 	streamer TransactionStreamerInterface,
 	recorder execution.ExecutionRecorder,
 	arbdb ethdb.Database,
-	dapReaders []daprovider.Reader,
+	daProviders []DAProvider,
 	config func() *BlockValidatorConfig,
 	stack *node.Node,
 	latestWasmModuleRoot common.Hash,
@@ -242,6 +260,7 @@ func NewStatelessBlockValidator(
 	var executionSpawners []validator.ExecutionSpawner
 	var boldExecutionSpawners []validator.BOLDExecutionSpawner
 	var redisValClient *redis.ValidationClient
+	var dispatcher *ValidationDispatcher
 
 	if config().RedisValidationClientConfig.Enabled() {
 		var err error
@@ -250,6 +269,13 @@ func NewStatelessBlockValidator(
 			return nil, fmt.Errorf("creating new redis validation client: %w", err)
 		}
 	}
+	if config().ValidationDispatcherConfig.Enabled() {
+		var err error
+		dispatcher, err = NewValidationDispatcher(func() *ValidationDispatcherConfig { return &config().ValidationDispatcherConfig })
+		if err != nil {
+			return nil, fmt.Errorf("creating validation dispatcher: %w", err)
+		}
+	}
 	configs := config().ValidationServerConfigs
 	for i := range configs {
 		i := i
@@ -267,15 +293,29 @@ func NewStatelessBlockValidator(
 		return nil, errors.New("latestWasmModuleRoot not set")
 	}
 
+	if err := validateBatchCacheConfig(config().BatchCacheSize, config().BatchCacheTTL); err != nil {
+		return nil, err
+	}
+	var batchCache *batchCache
+	if config().BatchCacheSize > 0 {
+		var cacheDB ethdb.Database
+		if config().BatchCachePersistent {
+			cacheDB = arbdb
+		}
+		batchCache = newBatchCache(config().BatchCacheSize, config().BatchCacheTTL, cacheDB)
+	}
+
 	return &StatelessBlockValidator{
 		config:               config(),
 		recorder:             recorder,
 		redisValidator:       redisValClient,
+		dispatcher:           dispatcher,
 		inboxReader:          inboxReader,
 		inboxTracker:         inbox,
 		streamer:             streamer,
 		db:                   arbdb,
-		dapReaders:           dapReaders,
+		batchCache:           batchCache,
+		daProviders:          daProviders,
 		execSpawners:         executionSpawners,
 		boldExecSpawners:     boldExecutionSpawners,
 		stack:                stack,
@@ -288,9 +328,22 @@ func (v *StatelessBlockValidator) readPostedBatch(ctx context.Context, batchNum
 	if err != nil {
 		return nil, err
 	}
+	if v.batchCache != nil {
+		v.batchCache.observeBatchCount(v, batchCount)
+	}
 	if batchCount <= batchNum {
 		return nil, fmt.Errorf("batch not found: %d", batchNum)
 	}
+	if v.batchCache != nil {
+		if batchAcc, err := v.inboxTracker.GetBatchAcc(batchNum); err == nil {
+			if info, ok := v.batchCache.get(batchNum); ok {
+				if info.Acc == batchAcc {
+					return info.PostedData, nil
+				}
+				v.InvalidateBatchCacheFrom(batchNum)
+			}
+		}
+	}
 	postedData, _, err := v.inboxReader.GetSequencerMessageBytes(ctx, batchNum)
 	return postedData, err
 }
@@ -308,9 +361,27 @@ func (v *StatelessBlockValidator) readFullBatch(ctx context.Context, batchNum ui
 	if err != nil {
 		return false, nil, err
 	}
+	if v.batchCache != nil {
+		v.batchCache.observeBatchCount(v, batchCount)
+	}
 	if batchCount <= batchNum {
 		return false, nil, nil
 	}
+	batchAcc, err := v.inboxTracker.GetBatchAcc(batchNum)
+	if err != nil {
+		return false, nil, err
+	}
+	if v.batchCache != nil {
+		if info, ok := v.batchCache.get(batchNum); ok {
+			if info.Acc == batchAcc {
+				return true, info, nil
+			}
+			// batchNum's contents changed under us - a reorg replaced it with
+			// a different batch without GetBatchCount ever dipping below
+			// batchNum - so the cached entry no longer describes this batch.
+			v.InvalidateBatchCacheFrom(batchNum)
+		}
+	}
 	batchMsgCount, err := v.inboxTracker.GetBatchMessageCount(batchNum)
 	if err != nil {
 		return false, nil, err
@@ -321,16 +392,17 @@ func (v *StatelessBlockValidator) readFullBatch(ctx context.Context, batchNum ui
 	}
 	preimages := make(daprovider.PreimagesMap)
 	if len(postedData) > 40 {
+		headerByte := postedData[40]
 		foundDA := false
-		for _, dapReader := range v.dapReaders {
-			if dapReader != nil && dapReader.IsValidHeaderByte(ctx, postedData[40]) {
+		for _, provider := range v.daProviders {
+			if provider.Reader != nil && provider.Reader.IsValidHeaderByte(ctx, headerByte) {
 				var err error
 				var preimagesRecorded daprovider.PreimagesMap
-				_, preimagesRecorded, err = dapReader.RecoverPayloadFromBatch(ctx, batchNum, batchBlockHash, postedData, preimages, true)
+				_, preimagesRecorded, err = provider.Reader.RecoverPayloadFromBatch(ctx, batchNum, batchBlockHash, postedData, preimages, true)
 				if err != nil {
 					// Matches the way keyset validation was done inside DAS readers i.e logging the error
 					//  But other daproviders might just want to return the error
-					if strings.Contains(err.Error(), daprovider.ErrSeqMsgValidation.Error()) && daprovider.IsDASMessageHeaderByte(postedData[40]) {
+					if strings.Contains(err.Error(), daprovider.ErrSeqMsgValidation.Error()) && daprovider.IsDASMessageHeaderByte(headerByte) {
 						log.Error(err.Error())
 					} else {
 						return false, nil, err
@@ -343,9 +415,10 @@ func (v *StatelessBlockValidator) readFullBatch(ctx context.Context, batchNum ui
 			}
 		}
 		if !foundDA {
-			if daprovider.IsDASMessageHeaderByte(postedData[40]) {
-				log.Error("No DAS Reader configured, but sequencer message found with DAS header")
+			if !daprovider.IsDASMessageHeaderByte(headerByte) {
+				return false, nil, fmt.Errorf("%w: header byte %#x", daprovider.ErrNoReaderForHeaderByte, headerByte)
 			}
+			log.Error("No DAS Reader configured, but sequencer message found with DAS header")
 		}
 	}
 	fullInfo := FullBatchInfo{
@@ -353,6 +426,10 @@ func (v *StatelessBlockValidator) readFullBatch(ctx context.Context, batchNum ui
 		PostedData: postedData,
 		MsgCount:   batchMsgCount,
 		Preimages:  preimages,
+		Acc:        batchAcc,
+	}
+	if v.batchCache != nil {
+		v.batchCache.put(batchNum, &fullInfo)
 	}
 	return true, &fullInfo, nil
 }
@@ -495,6 +572,22 @@ func (v *StatelessBlockValidator) CreateReadyValidationEntry(ctx context.Context
 	return entry, nil
 }
 
+// validateResultViaDispatcher enqueues input on the dispatcher and awaits its
+// result. Any error returned here (enqueue failure, a dropped stream, a
+// context timeout) means the dispatcher path couldn't be completed at all -
+// it says nothing about whether moduleRoot itself is valid - so callers
+// should fall back to a local validation path rather than treating it as a
+// validation failure.
+func (v *StatelessBlockValidator) validateResultViaDispatcher(
+	ctx context.Context, moduleRoot common.Hash, input *validator.ValidationInput,
+) (validator.GoGlobalState, error) {
+	promise, err := v.dispatcher.Enqueue(ctx, moduleRoot, input)
+	if err != nil {
+		return validator.GoGlobalState{}, err
+	}
+	return promise.Await(ctx)
+}
+
 func (v *StatelessBlockValidator) ValidateResult(
 	ctx context.Context, pos arbutil.MessageIndex, useExec bool, moduleRoot common.Hash,
 ) (bool, *validator.GoGlobalState, error) {
@@ -502,6 +595,31 @@ func (v *StatelessBlockValidator) ValidateResult(
 	if err != nil {
 		return false, nil, err
 	}
+	if !useExec && v.dispatcher != nil {
+		// Dispatcher workers are a heterogeneous, horizontally-scaled fleet,
+		// so the stylus archs available to whichever worker dequeues this
+		// entry aren't known here; include the archs configured locally as
+		// a stand-in for "every arch the fleet is expected to support".
+		var stylusArchs []ethdb.WasmTarget
+		if len(v.execSpawners) > 0 {
+			stylusArchs = v.execSpawners[0].StylusArchs()
+		}
+		input, err := entry.ToInput(stylusArchs)
+		if err != nil {
+			return false, nil, err
+		}
+		gsEnd, dispatchErr := v.validateResultViaDispatcher(ctx, moduleRoot, input)
+		if dispatchErr == nil {
+			if gsEnd != entry.End {
+				return false, &gsEnd, nil
+			}
+			return true, &entry.End, nil
+		}
+		// The dispatcher (or its Redis stream) is unavailable; fall back to
+		// redisValidator/execSpawners below rather than failing validation
+		// outright just because the work queue couldn't be reached.
+		log.Warn("validation dispatcher failed, falling back to direct execution", "err", dispatchErr)
+	}
 	var run validator.ValidationRun
 	if !useExec {
 		if v.redisValidator != nil {
@@ -546,9 +664,101 @@ func (v *StatelessBlockValidator) ValidationInputsAt(ctx context.Context, pos ar
 	if err != nil {
 		return server_api.InputJSON{}, err
 	}
+	// When a dispatcher is configured, route the input through the same
+	// fenced work queue ValidateResult uses rather than only handing back
+	// JSON for someone else to drive, so the fleet of TEE workers shares
+	// in validating this message too.
+	if v.dispatcher != nil {
+		promise, err := v.dispatcher.Enqueue(ctx, v.latestWasmModuleRoot, input)
+		if err != nil {
+			return server_api.InputJSON{}, err
+		}
+		gsEnd, err := promise.Await(ctx)
+		if err != nil {
+			return server_api.InputJSON{}, err
+		}
+		if gsEnd != entry.End {
+			return server_api.InputJSON{}, fmt.Errorf("dispatcher validation of message %d: expected end state %v, got %v", pos, entry.End, gsEnd)
+		}
+	}
 	return *server_api.ValidationInputToJson(input), nil
 }
 
+// BOLDInputJSON extends the usual validation input payload with the OSP
+// step index it was recorded for, so a `nitro val-server-bold` deployment
+// can be driven purely from the JSON without the block validator.
+type BOLDInputJSON struct {
+	server_api.InputJSON
+	StepIndex uint64
+}
+
+// ValidateResultBOLD runs the execution at pos to completion like
+// ValidateResult, but against a BOLDExecutionSpawner, and additionally
+// returns the intermediate machine hash at stepIndex so a BOLD challenge can
+// build a one-step proof around it.
+func (v *StatelessBlockValidator) ValidateResultBOLD(
+	ctx context.Context, pos arbutil.MessageIndex, moduleRoot common.Hash, stepIndex uint64,
+) (*validator.GoGlobalState, common.Hash, error) {
+	entry, err := v.CreateReadyValidationEntry(ctx, pos)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	var spawner validator.BOLDExecutionSpawner
+	for _, s := range v.boldExecSpawners {
+		if validator.SpawnerSupportsModule(s, moduleRoot) {
+			spawner = s
+			break
+		}
+	}
+	if spawner == nil {
+		return nil, common.Hash{}, fmt.Errorf("BOLD validation with WasmModuleRoot %v not supported by node", moduleRoot)
+	}
+	input, err := entry.ToInput(spawner.StylusArchs())
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	// Unlike a plain ExecutionSpawner's ValidationRun, a BOLD execution run
+	// exposes per-step machine state via promises, since a BOLD challenge
+	// needs arbitrary intermediate steps - not just the final state - to
+	// build a one-step proof.
+	run := spawner.Launch(input, moduleRoot)
+	defer run.Close()
+	lastStep, err := run.GetLastStep().Await(ctx)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	gsEnd := lastStep.GlobalState
+	if gsEnd != entry.End {
+		return &gsEnd, common.Hash{}, fmt.Errorf("validation failed: expected %v, got %v", entry.End, gsEnd)
+	}
+	stepResult, err := run.GetStepAt(stepIndex).Await(ctx)
+	if err != nil {
+		return &gsEnd, common.Hash{}, fmt.Errorf("getting machine hash at step %d: %w", stepIndex, err)
+	}
+	return &gsEnd, stepResult.Hash, nil
+}
+
+// ValidationInputsAtBOLD is ValidationInputsAt's counterpart for BOLD
+// one-step-proof generation: the returned payload carries stepIndex
+// alongside the usual validation input so an external prover can recreate
+// ValidateResultBOLD's machine hash without calling back into this node.
+func (v *StatelessBlockValidator) ValidationInputsAtBOLD(
+	ctx context.Context, pos arbutil.MessageIndex, stepIndex uint64, targets ...ethdb.WasmTarget,
+) (BOLDInputJSON, error) {
+	entry, err := v.CreateReadyValidationEntry(ctx, pos)
+	if err != nil {
+		return BOLDInputJSON{}, err
+	}
+	input, err := entry.ToInput(targets)
+	if err != nil {
+		return BOLDInputJSON{}, err
+	}
+	return BOLDInputJSON{
+		InputJSON: *server_api.ValidationInputToJson(input),
+		StepIndex: stepIndex,
+	}, nil
+}
+
 func (v *StatelessBlockValidator) OverrideRecorder(t *testing.T, recorder execution.ExecutionRecorder) {
 	v.recorder = recorder
 }
@@ -563,6 +773,9 @@ func (v *StatelessBlockValidator) Start(ctx_in context.Context) error {
 			return fmt.Errorf("starting execution spawner: %w", err)
 		}
 	}
+	if v.dispatcher != nil {
+		v.dispatcher.Start(ctx_in)
+	}
 	for _, spawner := range v.execSpawners {
 		if err := spawner.Start(ctx_in); err != nil {
 			return err
@@ -578,4 +791,7 @@ func (v *StatelessBlockValidator) Stop() {
 	if v.redisValidator != nil {
 		v.redisValidator.Stop()
 	}
+	if v.dispatcher != nil {
+		v.dispatcher.StopOnly()
+	}
 }
@@ -0,0 +1,308 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+
+	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/server_api"
+)
+
+// ValidationBundle is a self-contained, gzipped tarball capturing everything
+// CreateReadyValidationEntry would otherwise assemble from a live L1 node,
+// inbox tracker and transaction streamer: for each message in a contiguous
+// range it carries the ValidationInput (preimages, delayed message bytes and
+// UserWasms included) plus the GoGlobalState the message is expected to
+// produce. An untrusted feeder builds the bundle once; any TEE node can
+// later replay and attest to it fully offline.
+const (
+	bundleMessageInputFile = "input.json"
+	bundleMessageEndFile   = "end.json"
+)
+
+func bundleMessageDir(pos arbutil.MessageIndex) string {
+	return path.Join("messages", strconv.FormatUint(uint64(pos), 10))
+}
+
+// stylusArchsForBundle returns the union of stylus archs across every
+// configured execution spawner, so an exported bundle carries UserWasms for
+// whichever targets any importing TEE worker might run.
+func (v *StatelessBlockValidator) stylusArchsForBundle() []ethdb.WasmTarget {
+	seen := make(map[ethdb.WasmTarget]bool)
+	var archs []ethdb.WasmTarget
+	for _, spawner := range v.execSpawners {
+		for _, arch := range spawner.StylusArchs() {
+			if !seen[arch] {
+				seen[arch] = true
+				archs = append(archs, arch)
+			}
+		}
+	}
+	return archs
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Size: int64(len(data)),
+		Mode: 0o644,
+	}); err != nil {
+		return fmt.Errorf("writing tar header for %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("writing tar contents for %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeBundleMessage appends one message's input.json/end.json pair to tw.
+func writeBundleMessage(tw *tar.Writer, pos arbutil.MessageIndex, input *validator.ValidationInput, end validator.GoGlobalState) error {
+	inputJSON, err := json.Marshal(server_api.ValidationInputToJson(input))
+	if err != nil {
+		return fmt.Errorf("marshaling input for message %d: %w", pos, err)
+	}
+	if err := writeTarFile(tw, path.Join(bundleMessageDir(pos), bundleMessageInputFile), inputJSON); err != nil {
+		return err
+	}
+	endJSON, err := json.Marshal(end)
+	if err != nil {
+		return fmt.Errorf("marshaling expected end state for message %d: %w", pos, err)
+	}
+	return writeTarFile(tw, path.Join(bundleMessageDir(pos), bundleMessageEndFile), endJSON)
+}
+
+// ExportBundle writes a ValidationBundle covering messages [from, to] to w.
+func (v *StatelessBlockValidator) ExportBundle(ctx context.Context, from, to arbutil.MessageIndex, w io.Writer) error {
+	if to < from {
+		return fmt.Errorf("invalid bundle range: from %d > to %d", from, to)
+	}
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	targets := v.stylusArchsForBundle()
+
+	for pos := from; pos <= to; pos++ {
+		entry, err := v.CreateReadyValidationEntry(ctx, pos)
+		if err != nil {
+			return fmt.Errorf("building validation entry for message %d: %w", pos, err)
+		}
+		input, err := entry.ToInput(targets)
+		if err != nil {
+			return fmt.Errorf("converting validation entry %d to input: %w", pos, err)
+		}
+		if err := writeBundleMessage(tw, pos, input, entry.End); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing bundle tar writer: %w", err)
+	}
+	if err := gzw.Close(); err != nil {
+		return fmt.Errorf("closing bundle gzip writer: %w", err)
+	}
+	return nil
+}
+
+type bundleMessage struct {
+	pos   arbutil.MessageIndex
+	input *validator.ValidationInput
+	end   validator.GoGlobalState
+}
+
+// BundleAttestation summarizes a successfully re-validated ValidationBundle:
+// every message in [FromPos, ToPos] produced exactly the GoGlobalState the
+// bundle claimed, against the given moduleRoot. Digest is the value a TEE's
+// attestation key signs over.
+type BundleAttestation struct {
+	ModuleRoot common.Hash
+	FromPos    arbutil.MessageIndex
+	ToPos      arbutil.MessageIndex
+	FinalState validator.GoGlobalState
+}
+
+func (a *BundleAttestation) Digest() common.Hash {
+	var buf []byte
+	buf = append(buf, a.ModuleRoot.Bytes()...)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(a.FromPos))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(a.ToPos))
+	buf = append(buf, a.FinalState.Hash().Bytes()...)
+	return crypto.Keccak256Hash(buf)
+}
+
+// validateBundleContinuity checks that messages (already sorted by pos) form
+// one unbroken state transition chain: positions are consecutive, and each
+// message's claimed start state matches the previous message's claimed end
+// state. Without this, an untrusted feeder could splice together
+// independently-plausible but non-contiguous transitions - each individually
+// reproducible by the executor - and still walk away with a clean
+// attestation for the spliced range.
+func validateBundleContinuity(messages []*bundleMessage) error {
+	for i, msg := range messages {
+		if i == 0 {
+			continue
+		}
+		prev := messages[i-1]
+		if msg.pos != prev.pos+1 {
+			return fmt.Errorf("bundle is missing message(s) between %d and %d", prev.pos, msg.pos)
+		}
+		if msg.input.StartState != prev.end {
+			return fmt.Errorf("bundle message %d start state %v does not match message %d's claimed end state %v", msg.pos, msg.input.StartState, prev.pos, prev.end)
+		}
+	}
+	return nil
+}
+
+const (
+	// maxBundleEntrySize bounds how much decompressed data parseBundleMessages
+	// will read for a single tar entry. A ValidationBundle comes from an
+	// untrusted feeder, so without a cap a corrupt or malicious gzip stream
+	// could exhaust memory long before tar.Reader ever reports EOF.
+	maxBundleEntrySize = 64 << 20 // 64 MiB
+
+	// maxBundleEntries bounds how many tar entries a single bundle may
+	// contain, guarding against the same kind of untrusted input exhausting
+	// memory via many small entries instead of one large one.
+	maxBundleEntries = 1 << 20
+)
+
+// parseBundleMessages reads a ValidationBundle tarball and reconstructs its
+// per-message ValidationInput and expected end state, sorted by position.
+// It is pure deserialization: no execution, no continuity check.
+func parseBundleMessages(r io.Reader) ([]*bundleMessage, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("opening bundle gzip stream: %w", err)
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+
+	byPos := make(map[arbutil.MessageIndex]*bundleMessage)
+	entries := 0
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle tar entry: %w", err)
+		}
+		entries++
+		if entries > maxBundleEntries {
+			return nil, fmt.Errorf("bundle contains more than %d entries", maxBundleEntries)
+		}
+		dir, file := path.Split(hdr.Name)
+		posStr := path.Base(strings.TrimSuffix(dir, "/"))
+		pos, err := strconv.ParseUint(posStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bundle entry %q has non-numeric message position: %w", hdr.Name, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBundleEntrySize+1))
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle entry %q: %w", hdr.Name, err)
+		}
+		if len(data) > maxBundleEntrySize {
+			return nil, fmt.Errorf("bundle entry %q exceeds the %d byte limit", hdr.Name, maxBundleEntrySize)
+		}
+		msg := byPos[arbutil.MessageIndex(pos)]
+		if msg == nil {
+			msg = &bundleMessage{pos: arbutil.MessageIndex(pos)}
+			byPos[arbutil.MessageIndex(pos)] = msg
+		}
+		switch file {
+		case bundleMessageInputFile:
+			var inputJSON server_api.InputJSON
+			if err := json.Unmarshal(data, &inputJSON); err != nil {
+				return nil, fmt.Errorf("decoding input for message %d: %w", pos, err)
+			}
+			input, err := server_api.ValidationInputFromJson(&inputJSON)
+			if err != nil {
+				return nil, fmt.Errorf("converting input for message %d: %w", pos, err)
+			}
+			msg.input = input
+		case bundleMessageEndFile:
+			if err := json.Unmarshal(data, &msg.end); err != nil {
+				return nil, fmt.Errorf("decoding expected end state for message %d: %w", pos, err)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected bundle entry %q", hdr.Name)
+		}
+	}
+
+	messages := make([]*bundleMessage, 0, len(byPos))
+	for _, msg := range byPos {
+		if msg.input == nil {
+			return nil, fmt.Errorf("bundle message %d missing %s", msg.pos, bundleMessageInputFile)
+		}
+		messages = append(messages, msg)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].pos < messages[j].pos })
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("bundle contains no messages")
+	}
+	return messages, nil
+}
+
+// ValidateBundle replays every message in a ValidationBundle against
+// moduleRoot and returns an attestation if, and only if, the messages form
+// one contiguous chain (see validateBundleContinuity) and each one produces
+// exactly its claimed GoGlobalState. It never touches an L1 node, an inbox
+// tracker, or a transaction streamer - everything needed is in r.
+func (v *StatelessBlockValidator) ValidateBundle(ctx context.Context, r io.Reader, moduleRoot common.Hash) (*BundleAttestation, error) {
+	messages, err := parseBundleMessages(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBundleContinuity(messages); err != nil {
+		return nil, err
+	}
+
+	var spawner validator.ExecutionSpawner
+	for _, s := range v.execSpawners {
+		if validator.SpawnerSupportsModule(s, moduleRoot) {
+			spawner = s
+			break
+		}
+	}
+	if spawner == nil {
+		return nil, fmt.Errorf("validation with WasmModuleRoot %v not supported by node", moduleRoot)
+	}
+
+	var finalState validator.GoGlobalState
+	for _, msg := range messages {
+		run := spawner.Launch(msg.input, moduleRoot)
+		gsEnd, err := run.Await(ctx)
+		run.Cancel()
+		if err != nil {
+			return nil, fmt.Errorf("replaying message %d: %w", msg.pos, err)
+		}
+		if gsEnd != msg.end {
+			return nil, fmt.Errorf("message %d: expected end state %v, got %v", msg.pos, msg.end, gsEnd)
+		}
+		finalState = gsEnd
+	}
+
+	return &BundleAttestation{
+		ModuleRoot: moduleRoot,
+		FromPos:    messages[0].pos,
+		ToPos:      messages[len(messages)-1].pos,
+		FinalState: finalState,
+	}, nil
+}
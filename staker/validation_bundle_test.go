@@ -0,0 +1,139 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/validator"
+)
+
+func globalState(blockHash byte) validator.GoGlobalState {
+	return validator.GoGlobalState{BlockHash: common.Hash{blockHash}}
+}
+
+func TestValidateBundleContinuityAcceptsChain(t *testing.T) {
+	messages := []*bundleMessage{
+		{pos: 5, input: &validator.ValidationInput{StartState: globalState(0)}, end: globalState(1)},
+		{pos: 6, input: &validator.ValidationInput{StartState: globalState(1)}, end: globalState(2)},
+		{pos: 7, input: &validator.ValidationInput{StartState: globalState(2)}, end: globalState(3)},
+	}
+	if err := validateBundleContinuity(messages); err != nil {
+		t.Fatalf("unexpected error for a contiguous chain: %v", err)
+	}
+}
+
+func TestValidateBundleContinuityRejectsGap(t *testing.T) {
+	messages := []*bundleMessage{
+		{pos: 5, input: &validator.ValidationInput{StartState: globalState(0)}, end: globalState(1)},
+		{pos: 7, input: &validator.ValidationInput{StartState: globalState(1)}, end: globalState(2)},
+	}
+	if err := validateBundleContinuity(messages); err == nil {
+		t.Fatal("expected an error for a bundle with a missing message")
+	}
+}
+
+func TestValidateBundleContinuityRejectsSplicedState(t *testing.T) {
+	messages := []*bundleMessage{
+		{pos: 5, input: &validator.ValidationInput{StartState: globalState(0)}, end: globalState(1)},
+		// Claims to continue from pos 5, but its start state doesn't match
+		// what pos 5 actually produced - an attempt to splice in an
+		// unrelated transition.
+		{pos: 6, input: &validator.ValidationInput{StartState: globalState(99)}, end: globalState(2)},
+	}
+	if err := validateBundleContinuity(messages); err == nil {
+		t.Fatal("expected an error for a bundle with a spliced-in, non-matching state")
+	}
+}
+
+// TestBundleExportImportRoundTrip builds a bundle tarball the same way
+// ExportBundle does (via writeBundleMessage) and checks parseBundleMessages
+// - the deserialization half of ValidateBundle - recovers exactly what was
+// written, in position order, independent of any live execution spawner.
+func TestBundleExportImportRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	want := []*bundleMessage{
+		{pos: 10, input: &validator.ValidationInput{Id: 10, StartState: globalState(0)}, end: globalState(1)},
+		{pos: 11, input: &validator.ValidationInput{Id: 11, StartState: globalState(1)}, end: globalState(2)},
+		{pos: 12, input: &validator.ValidationInput{Id: 12, StartState: globalState(2)}, end: globalState(3)},
+	}
+	// Write in reverse to confirm parseBundleMessages sorts by position
+	// rather than relying on tar entry order.
+	for i := len(want) - 1; i >= 0; i-- {
+		msg := want[i]
+		if err := writeBundleMessage(tw, msg.pos, msg.input, msg.end); err != nil {
+			t.Fatalf("writeBundleMessage(%d): %v", msg.pos, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+
+	got, err := parseBundleMessages(&buf)
+	if err != nil {
+		t.Fatalf("parseBundleMessages: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d messages, want %d", len(got), len(want))
+	}
+	for i, msg := range got {
+		if msg.pos != want[i].pos {
+			t.Fatalf("message %d: got pos %d, want %d", i, msg.pos, want[i].pos)
+		}
+		if msg.input.Id != want[i].input.Id {
+			t.Fatalf("message %d: got input id %d, want %d", i, msg.input.Id, want[i].input.Id)
+		}
+		if msg.input.StartState != want[i].input.StartState {
+			t.Fatalf("message %d: got start state %v, want %v", i, msg.input.StartState, want[i].input.StartState)
+		}
+		if msg.end != want[i].end {
+			t.Fatalf("message %d: got end state %v, want %v", i, msg.end, want[i].end)
+		}
+	}
+	if err := validateBundleContinuity(got); err != nil {
+		t.Fatalf("round-tripped bundle should be continuous: %v", err)
+	}
+}
+
+// TestParseBundleMessagesRejectsOversizedEntry confirms an oversized tar
+// entry is rejected rather than fully buffered into memory, since a
+// ValidationBundle's bytes come from an untrusted feeder.
+func TestParseBundleMessagesRejectsOversizedEntry(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	oversized := bytes.Repeat([]byte{0}, maxBundleEntrySize+1)
+	if err := writeTarFile(tw, "messages/5/input.json", oversized); err != nil {
+		t.Fatalf("writeTarFile: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+
+	if _, err := parseBundleMessages(&buf); err == nil {
+		t.Fatal("expected an error for a tar entry exceeding maxBundleEntrySize")
+	}
+}
+
+func TestBundleMessageDir(t *testing.T) {
+	if got, want := bundleMessageDir(arbutil.MessageIndex(42)), "messages/42"; got != want {
+		t.Fatalf("bundleMessageDir(42) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/pubsub"
+	"github.com/offchainlabs/nitro/util/containers"
+	"github.com/offchainlabs/nitro/util/stopwaiter"
+	"github.com/offchainlabs/nitro/validator"
+)
+
+var (
+	validationQueueDepthGauge    = metrics.NewRegisteredGauge("arb/blockvalidator/dispatcher/queuedepth", nil)
+	validationReassignedCounter  = metrics.NewRegisteredCounter("arb/blockvalidator/dispatcher/reassigned", nil)
+	validationEnqueueLatencyTime = metrics.NewRegisteredTimer("arb/blockvalidator/dispatcher/enqueuelatency", nil)
+)
+
+// ValidationDispatcherConfig configures the redis stream(s) a
+// ValidationDispatcher enqueues onto, one stream per wasm module root.
+type ValidationDispatcherConfig struct {
+	RedisURL        string        `koanf:"redis-url"`
+	StreamPrefix    string        `koanf:"stream-prefix"`
+	ConsumerGroup   string        `koanf:"consumer-group"`
+	ProduceTimeout  time.Duration `koanf:"produce-timeout"`
+	ReclaimInterval time.Duration `koanf:"reclaim-interval"`
+	ReclaimMinIdle  time.Duration `koanf:"reclaim-min-idle"`
+}
+
+func (c *ValidationDispatcherConfig) Enabled() bool {
+	return c != nil && c.RedisURL != ""
+}
+
+var DefaultValidationDispatcherConfig = ValidationDispatcherConfig{
+	StreamPrefix:    "validation",
+	ConsumerGroup:   "validators",
+	ProduceTimeout:  time.Minute,
+	ReclaimInterval: 30 * time.Second,
+	ReclaimMinIdle:  5 * time.Minute,
+}
+
+type moduleRootProducer = pubsub.Producer[*validator.ValidationInput, validator.GoGlobalState]
+
+// ValidationDispatcher replaces the single-redisValidator-or-local-exec
+// choice in StatelessBlockValidator.ValidateResult with a fleet-friendly
+// work queue: validation inputs are enqueued onto a redis stream keyed by
+// module root, so any number of producer processes can submit work and any
+// number of TEE validation workers can consume it via a shared, fenced
+// consumer group.
+type ValidationDispatcher struct {
+	stopwaiter.StopWaiter
+
+	config func() *ValidationDispatcherConfig
+
+	// newProducer is the underlying redis stream producer constructor.
+	// Overridable in tests so producerFor's lazy-create/locking behavior can
+	// be exercised without a live redis.
+	newProducer func(streamName string) (*moduleRootProducer, error)
+
+	mu        sync.RWMutex
+	producers map[common.Hash]*moduleRootProducer
+}
+
+func NewValidationDispatcher(config func() *ValidationDispatcherConfig) (*ValidationDispatcher, error) {
+	if !config().Enabled() {
+		return nil, fmt.Errorf("validation dispatcher: redis url not configured")
+	}
+	d := &ValidationDispatcher{
+		config:    config,
+		producers: make(map[common.Hash]*moduleRootProducer),
+	}
+	d.newProducer = func(streamName string) (*moduleRootProducer, error) {
+		producer, err := pubsub.NewProducer[*validator.ValidationInput, validator.GoGlobalState](
+			config().RedisURL,
+			streamName,
+			&pubsub.ProducerConfig{
+				RequestTimeout: config().ProduceTimeout,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		producer.Start(d.GetContext())
+		return producer, nil
+	}
+	return d, nil
+}
+
+func (d *ValidationDispatcher) Start(ctx_in context.Context) {
+	d.StopWaiter.Start(ctx_in, d)
+	d.CallIteratively(d.reclaimLostMessages)
+}
+
+// producerFor returns the producer for moduleRoot, lazily creating the
+// underlying redis stream + consumer group the first time that module root
+// is seen.
+func (d *ValidationDispatcher) producerFor(moduleRoot common.Hash) (*moduleRootProducer, error) {
+	d.mu.RLock()
+	producer, ok := d.producers[moduleRoot]
+	d.mu.RUnlock()
+	if ok {
+		return producer, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if producer, ok := d.producers[moduleRoot]; ok {
+		return producer, nil
+	}
+	streamName := fmt.Sprintf("%s-%s", d.config().StreamPrefix, moduleRoot.Hex())
+	producer, err := d.newProducer(streamName)
+	if err != nil {
+		return nil, fmt.Errorf("creating producer for module root %v: %w", moduleRoot, err)
+	}
+	d.producers[moduleRoot] = producer
+	return producer, nil
+}
+
+// Enqueue submits input for validation against moduleRoot and returns a
+// promise resolved once some worker in the consumer group processes it.
+// Backpressure and per-call timeouts are enforced by the underlying
+// producer's RequestTimeout.
+func (d *ValidationDispatcher) Enqueue(
+	ctx context.Context, moduleRoot common.Hash, input *validator.ValidationInput,
+) (containers.PromiseInterface[validator.GoGlobalState], error) {
+	start := time.Now()
+	producer, err := d.producerFor(moduleRoot)
+	if err != nil {
+		return nil, err
+	}
+	promise, err := producer.Produce(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("enqueueing validation input for module root %v: %w", moduleRoot, err)
+	}
+	validationEnqueueLatencyTime.UpdateSince(start)
+	validationQueueDepthGauge.Update(int64(producer.StreamLen(ctx)))
+	return promise, nil
+}
+
+// reclaimLostMessages runs periodically and hands entries claimed by a
+// consumer that died mid-processing (no ack within ReclaimMinIdle) to a
+// live consumer via XAUTOCLAIM, so a crashed TEE worker never stalls a
+// module root's queue.
+func (d *ValidationDispatcher) reclaimLostMessages(ctx context.Context) time.Duration {
+	d.mu.RLock()
+	producers := make([]*moduleRootProducer, 0, len(d.producers))
+	for _, p := range d.producers {
+		producers = append(producers, p)
+	}
+	d.mu.RUnlock()
+
+	for _, producer := range producers {
+		reclaimed, err := producer.ReclaimLostMessages(ctx, d.config().ReclaimMinIdle)
+		if err != nil {
+			log.Error("validation dispatcher: reclaiming lost messages", "err", err)
+			continue
+		}
+		if reclaimed > 0 {
+			validationReassignedCounter.Inc(int64(reclaimed))
+		}
+	}
+	return d.config().ReclaimInterval
+}
@@ -0,0 +1,109 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package staker
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestValidationDispatcherConfigEnabled(t *testing.T) {
+	var disabled *ValidationDispatcherConfig
+	if disabled.Enabled() {
+		t.Fatal("nil config should not be enabled")
+	}
+	cfg := DefaultValidationDispatcherConfig
+	if cfg.Enabled() {
+		t.Fatal("config without a redis url should not be enabled")
+	}
+	cfg.RedisURL = "redis://localhost:6379"
+	if !cfg.Enabled() {
+		t.Fatal("config with a redis url should be enabled")
+	}
+}
+
+func TestNewValidationDispatcherRequiresRedisURL(t *testing.T) {
+	cfg := DefaultValidationDispatcherConfig
+	if _, err := NewValidationDispatcher(func() *ValidationDispatcherConfig { return &cfg }); err == nil {
+		t.Fatal("expected an error when redis url is not configured")
+	}
+}
+
+// newTestDispatcher builds a ValidationDispatcher whose newProducer is a
+// counting stub instead of one backed by a live redis, so producerFor's
+// lazy-create/double-checked-locking path can be exercised directly.
+func newTestDispatcher(t *testing.T) (*ValidationDispatcher, *int32) {
+	t.Helper()
+	cfg := DefaultValidationDispatcherConfig
+	cfg.RedisURL = "redis://localhost:6379"
+	d, err := NewValidationDispatcher(func() *ValidationDispatcherConfig { return &cfg })
+	if err != nil {
+		t.Fatalf("NewValidationDispatcher: %v", err)
+	}
+	var calls int32
+	d.newProducer = func(streamName string) (*moduleRootProducer, error) {
+		atomic.AddInt32(&calls, 1)
+		return &moduleRootProducer{}, nil
+	}
+	return d, &calls
+}
+
+func TestProducerForCreatesOncePerModuleRoot(t *testing.T) {
+	d, calls := newTestDispatcher(t)
+	moduleRoot := common.HexToHash("0x1")
+
+	first, err := d.producerFor(moduleRoot)
+	if err != nil {
+		t.Fatalf("producerFor: %v", err)
+	}
+	second, err := d.producerFor(moduleRoot)
+	if err != nil {
+		t.Fatalf("producerFor: %v", err)
+	}
+	if first != second {
+		t.Fatal("expected the same producer to be returned for the same module root")
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Fatalf("expected newProducer to be called once, got %d", got)
+	}
+}
+
+func TestProducerForIsConcurrencySafe(t *testing.T) {
+	d, calls := newTestDispatcher(t)
+	moduleRoot := common.HexToHash("0x2")
+
+	const goroutines = 32
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := d.producerFor(moduleRoot); err != nil {
+				t.Errorf("producerFor: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Fatalf("expected newProducer to be called once despite concurrent callers, got %d", got)
+	}
+}
+
+func TestProducerForDistinctModuleRoots(t *testing.T) {
+	d, calls := newTestDispatcher(t)
+
+	if _, err := d.producerFor(common.HexToHash("0x1")); err != nil {
+		t.Fatalf("producerFor: %v", err)
+	}
+	if _, err := d.producerFor(common.HexToHash("0x2")); err != nil {
+		t.Fatalf("producerFor: %v", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Fatalf("expected newProducer to be called once per distinct module root, got %d", got)
+	}
+}